@@ -0,0 +1,36 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// WebhookEventType returns the event type of a webhook request, as carried
+// in the X-Gitlab-Event header.
+//
+// GitLab API docs: https://docs.gitlab.com/ee/user/project/integrations/webhook_events.html
+func WebhookEventType(r *http.Request) EventType {
+	return EventType(r.Header.Get("X-Gitlab-Event"))
+}
+
+// ParseWebhook parses the event payload. For recognized event types, a
+// value of the corresponding struct is returned. An error will be returned
+// for unrecognized event types.
+//
+// ParseWebhook currently only recognizes the External Status Check Hook
+// event; additional event types are expected to be added here over time.
+func ParseWebhook(eventType EventType, payload []byte) (event interface{}, err error) {
+	switch eventType {
+	case EventTypeExternalStatusCheck:
+		event = &ExternalStatusCheckEvent{}
+	default:
+		return nil, fmt.Errorf("unexpected event type: %s", eventType)
+	}
+
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
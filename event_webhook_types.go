@@ -0,0 +1,57 @@
+package gitlab
+
+// EventType represents a GitLab event type, as found in the X-Gitlab-Event
+// header of an incoming webhook request.
+//
+// GitLab API docs: https://docs.gitlab.com/ee/user/project/integrations/webhook_events.html
+type EventType string
+
+// List of available event types.
+const (
+	EventTypeExternalStatusCheck EventType = "External Status Check Hook"
+)
+
+// EventUser represents a GitLab user, as included in the payload of many
+// webhook event types.
+type EventUser struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Username  string `json:"username"`
+	AvatarURL string `json:"avatar_url"`
+	Email     string `json:"email"`
+}
+
+// ExternalStatusCheckEventMergeRequest holds the merge request details
+// included in an ExternalStatusCheckEvent payload.
+type ExternalStatusCheckEventMergeRequest struct {
+	IID          int    `json:"iid"`
+	Title        string `json:"title"`
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+	State        string `json:"state"`
+}
+
+// ExternalStatusCheckEventRule holds the external approval rule details
+// included in an ExternalStatusCheckEvent payload.
+type ExternalStatusCheckEventRule struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	ExternalURL string `json:"external_url"`
+}
+
+// ExternalStatusCheckEvent represents the payload GitLab POSTs to a status
+// check's external_url when a merge request requires a status check
+// response.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/user/project/merge_requests/status_checks.html#request-header-and-payload
+type ExternalStatusCheckEvent struct {
+	ObjectKind           string                               `json:"object_kind"`
+	EventType            string                               `json:"event_type"`
+	User                 *EventUser                           `json:"user"`
+	ProjectID            int                                  `json:"project_id"`
+	MergeRequest         ExternalStatusCheckEventMergeRequest `json:"merge_request"`
+	SHA                  string                               `json:"sha"`
+	ExternalApprovalRule ExternalStatusCheckEventRule         `json:"external_approval_rule"`
+	CallbackURL          string                               `json:"callback_url"`
+}
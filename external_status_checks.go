@@ -1,6 +1,8 @@
 package gitlab
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -41,6 +43,9 @@ type StatusCheckProtectedBranch struct {
 // ListMergeStatusChecks lists the external status checks that apply to it
 // and their status for a single merge request.
 //
+// When the Client was configured with WithRetryableHTTPClient, this request
+// is retried on 5xx/429 responses by default.
+//
 // GitLab API docs:
 // https://docs.gitlab.com/ee/api/status_checks.html#list-status-checks-for-a-merge-request
 func (s *ExternalStatusChecksService) ListMergeStatusChecks(pid interface{}, mr int, opt *ListOptions, options ...RequestOptionFunc) ([]*MergeStatusCheck, *Response, error) {
@@ -89,6 +94,87 @@ func (s *ExternalStatusChecksService) ListProjectStatusChecks(pid interface{}, o
 	return pscs, resp, err
 }
 
+// GetExternalStatusCheck gets a single project external status check.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/status_checks.html#get-project-external-status-checks
+func (s *ExternalStatusChecksService) GetExternalStatusCheck(pid interface{}, checkID int, options ...RequestOptionFunc) (*ProjectStatusCheck, *Response, error) {
+	project, err := parseID(pid)
+	if err != nil {
+		return nil, nil, err
+	}
+	u := fmt.Sprintf("projects/%s/external_status_checks/%d", PathEscape(project), checkID)
+
+	req, err := s.client.NewRequest(http.MethodGet, u, nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	psc := new(ProjectStatusCheck)
+	resp, err := s.client.Do(req, psc)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return psc, resp, err
+}
+
+type ListGroupStatusChecksOptions struct {
+	ListOptions
+	IncludeSubgroups *bool `url:"include_subgroups,omitempty" json:"include_subgroups,omitempty"`
+}
+
+// ListGroupStatusChecks lists every project external status check across a
+// group (and its subgroups, when IncludeSubgroups is set), by walking
+// GET /groups/:id/projects and fanning out to each project's external
+// status checks.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/status_checks.html#get-project-external-status-checks
+func (s *ExternalStatusChecksService) ListGroupStatusChecks(gid interface{}, opt *ListGroupStatusChecksOptions, options ...RequestOptionFunc) ([]*ProjectStatusCheck, *Response, error) {
+	group, err := parseID(gid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if opt == nil {
+		opt = &ListGroupStatusChecksOptions{}
+	}
+
+	projectsOpt := &ListGroupProjectsOptions{
+		ListOptions:      opt.ListOptions,
+		IncludeSubGroups: opt.IncludeSubgroups,
+	}
+
+	var pscs []*ProjectStatusCheck
+	var resp *Response
+
+	for {
+		projects, r, err := s.client.Groups.ListGroupProjects(group, projectsOpt, options...)
+		resp = r
+		if err != nil {
+			return nil, resp, err
+		}
+
+		for _, project := range projects {
+			it := s.ListProjectStatusChecksIter(project.ID, nil, options...)
+			for it.Next() {
+				pscs = append(pscs, it.Value())
+			}
+			if err := it.Err(); err != nil {
+				return nil, resp, err
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		projectsOpt.Page = resp.NextPage
+	}
+
+	return pscs, resp, nil
+}
+
 type SetExternalStatusCheckStatusOptions struct {
 	SHA                   *string `url:"sha" json:"sha"`
 	ExternalStatusCheckID *int    `url:"external_status_check_id" json:"external_status_check_id"`
@@ -97,6 +183,12 @@ type SetExternalStatusCheckStatusOptions struct {
 
 // SetExternalStatusCheckStatus set status of an external status check
 //
+// GitLab returns 409/422 while the SHA or pipeline this status check
+// reports on is still being indexed, so this request opts into retrying
+// those specific codes when the Client was configured with
+// WithRetryableHTTPClient. As a POST, it is not retried on the default
+// 5xx/429 codes.
+//
 // Gitlab API docs:
 // https://docs.gitlab.com/ee/api/status_checks.html#set-status-of-an-external-status-check
 func (s *ExternalStatusChecksService) SetExternalStatusCheckStatus(pid interface{}, mergeRequestIID int, opt *SetExternalStatusCheckStatusOptions, options ...RequestOptionFunc) (*Response, error) {
@@ -106,6 +198,8 @@ func (s *ExternalStatusChecksService) SetExternalStatusCheckStatus(pid interface
 	}
 	u := fmt.Sprintf("projects/%s/merge_requests/%d/status_check_responses", PathEscape(project), mergeRequestIID)
 
+	options = append(options, WithRetryOn(http.StatusConflict, http.StatusUnprocessableEntity))
+
 	req, err := s.client.NewRequest(http.MethodPost, u, opt, options)
 	if err != nil {
 		return nil, err
@@ -120,19 +214,46 @@ type CreateExternalStatusCheck struct {
 	ProtectedBranchIDs *[]int  `url:"protected_branch_ids,omitempty" json:"protected_branch_ids,omitempty"`
 }
 
-func (s *ExternalStatusChecksService) CreateExternalStatusCheck(pid interface{}, opt *CreateExternalStatusCheck, options ...RequestOptionFunc) (*Response, error) {
+// CreateExternalStatusCheck creates a new project external status check.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/status_checks.html#create-external-status-check
+func (s *ExternalStatusChecksService) CreateExternalStatusCheck(pid interface{}, opt *CreateExternalStatusCheck, options ...RequestOptionFunc) (*ProjectStatusCheck, *Response, error) {
 	project, err := parseID(pid)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	u := fmt.Sprintf("projects/%s/external_status_checks", PathEscape(project))
 
 	req, err := s.client.NewRequest(http.MethodPost, u, opt, options)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return s.client.Do(req, nil)
+	psc := new(ProjectStatusCheck)
+	resp, err := s.client.Do(req, psc)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return psc, resp, err
+}
+
+// RespondToEvent responds to an ExternalStatusCheckEvent (the payload
+// GitLab POSTs to a status check's external_url) by setting the status of
+// the check the event refers to, filling in the SHA, external status check
+// ID and merge request from the event itself.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/status_checks.html#set-status-of-an-external-status-check
+func (s *ExternalStatusChecksService) RespondToEvent(ev *ExternalStatusCheckEvent, status string, options ...RequestOptionFunc) (*Response, error) {
+	opt := &SetExternalStatusCheckStatusOptions{
+		SHA:                   &ev.SHA,
+		ExternalStatusCheckID: &ev.ExternalApprovalRule.ID,
+		Status:                &status,
+	}
+
+	return s.SetExternalStatusCheckStatus(ev.ProjectID, ev.MergeRequest.IID, opt, options...)
 }
 
 func (s *ExternalStatusChecksService) DeleteExternalStatusCheck(pid interface{}, checkID int, options ...RequestOptionFunc) (*Response, error) {
@@ -157,18 +278,197 @@ type UpdateExternalStatusCheckOptions struct {
 	ProtectedBranchIDs *[]int  `url:"protected_branch_ids,omitempty" json:"protected_branch_ids,omitempty"`
 }
 
-func (s *ExternalStatusChecksService) UpdateExternalStatusCheck(pid interface{}, checkID int, options ...RequestOptionFunc) (*Response, error) {
+// UpdateExternalStatusCheck updates a project external status check.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/status_checks.html#update-external-status-check
+func (s *ExternalStatusChecksService) UpdateExternalStatusCheck(pid interface{}, checkID int, opt *UpdateExternalStatusCheckOptions, options ...RequestOptionFunc) (*ProjectStatusCheck, *Response, error) {
 	project, err := parseID(pid)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	u := fmt.Sprintf("projects/%s/external_status_checks/%d", PathEscape(project), checkID)
 
-	req, err := s.client.NewRequest(http.MethodPut, u, &UpdateExternalStatusCheckOptions{}, options)
+	req, err := s.client.NewRequest(http.MethodPut, u, opt, options)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return s.client.Do(req, nil)
+	psc := new(ProjectStatusCheck)
+	resp, err := s.client.Do(req, psc)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return psc, resp, err
+}
+
+// ErrSHAChanged is returned by WaitForChecks when the merge request's head
+// SHA changes while waiting, e.g. because of a force-push or rebase. The
+// check statuses gathered so far no longer apply to the current head, so
+// callers should restart the wait against the new SHA.
+var ErrSHAChanged = errors.New("gitlab: merge request head SHA changed while waiting for checks")
+
+// WaitForChecksOptions configures WaitForChecks.
+type WaitForChecksOptions struct {
+	// PollInterval is the delay between polls of ListMergeStatusChecks.
+	// Defaults to 5 seconds when zero.
+	PollInterval time.Duration
+	// RequiredCheckIDs restricts which checks must pass for the gate to
+	// succeed. An empty slice means all checks returned for the merge
+	// request are required.
+	RequiredCheckIDs []int
+	// FailFast returns as soon as any required check fails, instead of
+	// waiting for the remaining checks to settle.
+	FailFast bool
+	// SHA pins the merge request head SHA WaitForChecks watches. If nil,
+	// the merge request's current head SHA is used. If set, it is checked
+	// against the merge request's actual head SHA on the first poll and
+	// ErrSHAChanged is returned immediately on a mismatch.
+	SHA *string
+}
+
+// CheckResult is the final status of a single external status check, as
+// reported by WaitForChecks.
+type CheckResult struct {
+	ID      int
+	Name    string
+	Status  string
+	Elapsed time.Duration
+}
+
+// CheckSummary is returned by WaitForChecks once every required check has
+// settled, or FailFast short-circuited on a failure.
+type CheckSummary struct {
+	Checks  []*CheckResult
+	Passed  bool
+	Elapsed time.Duration
+}
+
+// WaitForChecks polls ListMergeStatusChecks until every required external
+// status check for a merge request has passed or failed, or ctx is done.
+// It reuses the retryable transport configured via WithRetryableHTTPClient,
+// if any, since it simply drives the existing ListMergeStatusChecks
+// request.
+//
+// If opt.SHA is set, it is validated against the merge request's actual
+// head SHA on the very first poll, so a stale or mistaken pin is caught
+// immediately rather than only once a later poll observes drift. If the
+// merge request's head SHA changes while waiting (e.g. a force-push),
+// WaitForChecks returns ErrSHAChanged rather than reporting stale
+// results.
+func (s *ExternalStatusChecksService) WaitForChecks(ctx context.Context, pid interface{}, mrIID int, opt *WaitForChecksOptions) (*CheckSummary, error) {
+	if opt == nil {
+		opt = &WaitForChecksOptions{}
+	}
+
+	pollInterval := opt.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = 5 * time.Second
+	}
+
+	start := time.Now()
+	var watchSHA string
+	first := true
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		mr, _, err := s.client.MergeRequests.GetMergeRequest(pid, mrIID, nil, WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case first && opt.SHA != nil && *opt.SHA != mr.SHA:
+			// The caller pinned a SHA that doesn't match the merge
+			// request's actual head; there's nothing to wait on.
+			return nil, ErrSHAChanged
+		case first:
+			watchSHA = mr.SHA
+		case mr.SHA != watchSHA:
+			return nil, ErrSHAChanged
+		}
+		first = false
+
+		mscs, _, err := s.ListMergeStatusChecks(pid, mrIID, nil, WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		if summary, done := summarizeChecks(mscs, opt.RequiredCheckIDs, opt.FailFast, start); done {
+			return summary, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// summarizeChecks reports whether every required check has appeared in
+// mscs and settled, building the CheckSummary to return once it has. A
+// required check that hasn't shown up yet (not yet registered on the MR,
+// or a typo'd ID) keeps the gate open rather than being treated as passed;
+// with no RequiredCheckIDs, the gate stays open until mscs is non-empty and
+// every check in it has settled, so an MR with no checks yet never reports
+// a false Passed:true.
+func summarizeChecks(mscs []*MergeStatusCheck, required []int, failFast bool, start time.Time) (*CheckSummary, bool) {
+	results := make([]*CheckResult, 0, len(mscs))
+	seen := make(map[int]bool, len(required))
+	anyFailed := false
+	allDone := true
+
+	for _, msc := range mscs {
+		if len(required) > 0 && !containsInt(required, msc.ID) {
+			continue
+		}
+		seen[msc.ID] = true
+
+		results = append(results, &CheckResult{
+			ID:      msc.ID,
+			Name:    msc.Name,
+			Status:  msc.Status,
+			Elapsed: time.Since(start),
+		})
+
+		switch msc.Status {
+		case "failed":
+			anyFailed = true
+		case "passed":
+		default:
+			allDone = false
+		}
+	}
+
+	if anyFailed && failFast {
+		return &CheckSummary{Checks: results, Passed: false, Elapsed: time.Since(start)}, true
+	}
+
+	for _, id := range required {
+		if !seen[id] {
+			return nil, false
+		}
+	}
+	if len(required) == 0 && len(results) == 0 {
+		return nil, false
+	}
+	if !allDone {
+		return nil, false
+	}
+
+	return &CheckSummary{Checks: results, Passed: !anyFailed, Elapsed: time.Since(start)}, true
+}
+
+func containsInt(ids []int, id int) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,313 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseWebhook_ExternalStatusCheckEvent_RespondToEvent(t *testing.T) {
+	payload := []byte(`{
+		"object_kind": "external_status_check",
+		"event_type": "approval_rule",
+		"project_id": 1,
+		"merge_request": {"iid": 2, "source_branch": "feature", "target_branch": "main", "state": "opened"},
+		"sha": "abc123",
+		"external_approval_rule": {"id": 7, "name": "QA", "external_url": "https://example.com/hook"},
+		"callback_url": "https://gitlab.example.com/api/v4/projects/1/merge_requests/2/status_check_responses"
+	}`)
+
+	event, err := ParseWebhook(EventTypeExternalStatusCheck, payload)
+	if err != nil {
+		t.Fatalf("ParseWebhook() returned error: %v", err)
+	}
+
+	ev, ok := event.(*ExternalStatusCheckEvent)
+	if !ok {
+		t.Fatalf("ParseWebhook() returned %T, want *ExternalStatusCheckEvent", event)
+	}
+	if ev.SHA != "abc123" || ev.ExternalApprovalRule.ID != 7 || ev.MergeRequest.IID != 2 {
+		t.Fatalf("unexpected event fields: %+v", ev)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/1/merge_requests/2/status_check_responses", func(w http.ResponseWriter, r *http.Request) {
+		var body SetExternalStatusCheckStatusOptions
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if body.SHA == nil || *body.SHA != "abc123" {
+			t.Errorf("SHA = %v, want abc123", body.SHA)
+		}
+		if body.ExternalStatusCheckID == nil || *body.ExternalStatusCheckID != 7 {
+			t.Errorf("ExternalStatusCheckID = %v, want 7", body.ExternalStatusCheckID)
+		}
+		if body.Status == nil || *body.Status != "passed" {
+			t.Errorf("Status = %v, want passed", body.Status)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient("", WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %v", err)
+	}
+
+	if _, err := client.ExternalStatusChecks.RespondToEvent(ev, "passed"); err != nil {
+		t.Fatalf("RespondToEvent() returned error: %v", err)
+	}
+}
+
+func TestGetExternalStatusCheck(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/1/external_status_checks/2", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Method = %s, want GET", r.Method)
+		}
+		fmt.Fprint(w, `{"id":2,"name":"QA","project_id":1,"external_url":"https://example.com/hook"}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient("", WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %v", err)
+	}
+
+	psc, _, err := client.ExternalStatusChecks.GetExternalStatusCheck(1, 2)
+	if err != nil {
+		t.Fatalf("GetExternalStatusCheck() returned error: %v", err)
+	}
+	if psc.ID != 2 || psc.Name != "QA" {
+		t.Errorf("got %+v, want ID=2 Name=QA", psc)
+	}
+}
+
+func TestCreateExternalStatusCheck_ReturnsCreatedCheck(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/1/external_status_checks", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Method = %s, want POST", r.Method)
+		}
+		fmt.Fprint(w, `{"id":9,"name":"QA","project_id":1,"external_url":"https://example.com/hook"}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient("", WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %v", err)
+	}
+
+	name := "QA"
+	url := "https://example.com/hook"
+	psc, _, err := client.ExternalStatusChecks.CreateExternalStatusCheck(1, &CreateExternalStatusCheck{Name: &name, ExternalURL: &url})
+	if err != nil {
+		t.Fatalf("CreateExternalStatusCheck() returned error: %v", err)
+	}
+	if psc.ID != 9 {
+		t.Errorf("ID = %d, want the server-assigned 9", psc.ID)
+	}
+}
+
+func TestUpdateExternalStatusCheck_SendsOptionsAndReturnsCheck(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/1/external_status_checks/2", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("Method = %s, want PUT", r.Method)
+		}
+
+		var body UpdateExternalStatusCheckOptions
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if body.Name == nil || *body.Name != "QA renamed" {
+			t.Errorf("request body Name = %v, want the caller's opt to have been sent, not an empty struct", body.Name)
+		}
+
+		fmt.Fprint(w, `{"id":2,"name":"QA renamed","project_id":1,"external_url":"https://example.com/hook"}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient("", WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %v", err)
+	}
+
+	name := "QA renamed"
+	psc, _, err := client.ExternalStatusChecks.UpdateExternalStatusCheck(1, 2, &UpdateExternalStatusCheckOptions{Name: &name})
+	if err != nil {
+		t.Fatalf("UpdateExternalStatusCheck() returned error: %v", err)
+	}
+	if psc.Name != "QA renamed" {
+		t.Errorf("Name = %q, want QA renamed", psc.Name)
+	}
+}
+
+func TestWaitForChecks_RejectsStaleSHAPinImmediately(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/1/merge_requests/2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"iid":2,"sha":"current-sha"}`)
+	})
+	mux.HandleFunc("/api/v4/projects/1/merge_requests/2/status_checks", func(w http.ResponseWriter, r *http.Request) {
+		// Stale results left over from the pinned, no-longer-current SHA:
+		// if WaitForChecks trusted the pin without checking mr.SHA first,
+		// this would report Passed:true for the wrong commit.
+		fmt.Fprint(w, `[{"id":1,"name":"QA","status":"passed"}]`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient("", WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %v", err)
+	}
+
+	stalePin := "stale-sha"
+	_, err = client.ExternalStatusChecks.WaitForChecks(context.Background(), 1, 2, &WaitForChecksOptions{SHA: &stalePin})
+	if !errors.Is(err, ErrSHAChanged) {
+		t.Fatalf("WaitForChecks() error = %v, want ErrSHAChanged", err)
+	}
+}
+
+func TestListProjectStatusChecksIter_FollowsPagination(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/1/external_status_checks", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"id":2,"project_id":1}]`)
+			return
+		}
+		w.Header().Set("X-Next-Page", "2")
+		fmt.Fprint(w, `[{"id":1,"project_id":1}]`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient("", WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %v", err)
+	}
+
+	var ids []int
+	it := client.ExternalStatusChecks.ListProjectStatusChecksIter(1, nil)
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("iterator returned error: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("got ids %v, want [1 2] across both pages", ids)
+	}
+}
+
+func TestListGroupStatusChecks_FansOutAcrossProjects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/groups/1/projects", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":1},{"id":2}]`)
+	})
+	mux.HandleFunc("/api/v4/projects/1/external_status_checks", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":10,"project_id":1}]`)
+	})
+	mux.HandleFunc("/api/v4/projects/2/external_status_checks", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":20,"project_id":2}]`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient("", WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %v", err)
+	}
+
+	// Passing a nil opt must not panic (opt.ListOptions is otherwise
+	// dereferenced before any nil check).
+	pscs, _, err := client.ExternalStatusChecks.ListGroupStatusChecks(1, nil)
+	if err != nil {
+		t.Fatalf("ListGroupStatusChecks() returned error: %v", err)
+	}
+
+	if len(pscs) != 2 {
+		t.Fatalf("got %d checks, want 2", len(pscs))
+	}
+	byProject := map[int]int{}
+	for _, psc := range pscs {
+		byProject[psc.ProjectID] = psc.ID
+	}
+	if byProject[1] != 10 || byProject[2] != 20 {
+		t.Errorf("got %v, want checks tagged with their originating project", byProject)
+	}
+}
+
+func TestSummarizeChecks(t *testing.T) {
+	start := time.Now()
+
+	tests := []struct {
+		name       string
+		mscs       []*MergeStatusCheck
+		required   []int
+		failFast   bool
+		wantDone   bool
+		wantPassed bool
+		wantChecks int
+	}{
+		{
+			name:     "missing required check keeps the gate open",
+			mscs:     []*MergeStatusCheck{{ID: 1, Status: "passed"}},
+			required: []int{1, 2},
+			wantDone: false,
+		},
+		{
+			name:       "fail fast returns as soon as one required check fails",
+			mscs:       []*MergeStatusCheck{{ID: 1, Status: "failed"}, {ID: 2, Status: "pending"}},
+			required:   []int{1, 2},
+			failFast:   true,
+			wantDone:   true,
+			wantPassed: false,
+			wantChecks: 2,
+		},
+		{
+			name:     "empty mscs keeps the gate open",
+			mscs:     nil,
+			required: nil,
+			wantDone: false,
+		},
+		{
+			name:       "all passed closes the gate",
+			mscs:       []*MergeStatusCheck{{ID: 1, Status: "passed"}, {ID: 2, Status: "passed"}},
+			required:   nil,
+			wantDone:   true,
+			wantPassed: true,
+			wantChecks: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary, done := summarizeChecks(tt.mscs, tt.required, tt.failFast, start)
+			if done != tt.wantDone {
+				t.Fatalf("done = %v, want %v", done, tt.wantDone)
+			}
+			if !done {
+				if summary != nil {
+					t.Fatalf("expected nil summary when not done, got %+v", summary)
+				}
+				return
+			}
+			if summary.Passed != tt.wantPassed {
+				t.Errorf("Passed = %v, want %v", summary.Passed, tt.wantPassed)
+			}
+			if len(summary.Checks) != tt.wantChecks {
+				t.Errorf("len(Checks) = %d, want %d", len(summary.Checks), tt.wantChecks)
+			}
+		})
+	}
+}
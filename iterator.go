@@ -0,0 +1,89 @@
+package gitlab
+
+// Iterator paginates the results of a List* method one page at a time,
+// transparently following the X-Next-Page header so callers do not have
+// to loop on ListOptions.Page themselves. It is generic over the item
+// type so it can back any List* method's pagination, not just status
+// checks; StatusCheckIterator is the first consumer.
+type Iterator[T any] struct {
+	fetch   func(page int) ([]*T, *Response, error)
+	buf     []*T
+	current *T
+	page    int
+	done    bool
+	err     error
+}
+
+// newIterator builds an Iterator starting at page, fetching subsequent
+// pages via fetch as Next is called.
+func newIterator[T any](page int, fetch func(page int) ([]*T, *Response, error)) *Iterator[T] {
+	return &Iterator[T]{page: page, fetch: fetch}
+}
+
+// Next advances the iterator to the next item, fetching the next page on
+// demand. It returns false once the list is exhausted or an error
+// occurred, in which case Err reports the error, if any.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if len(it.buf) == 0 {
+		if it.done {
+			return false
+		}
+
+		items, resp, err := it.fetch(it.page)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.buf = items
+		if resp == nil || resp.NextPage == 0 {
+			it.done = true
+		} else {
+			it.page = resp.NextPage
+		}
+
+		if len(it.buf) == 0 {
+			return false
+		}
+	}
+
+	it.current, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Value returns the item Next just advanced to.
+func (it *Iterator[T]) Value() *T {
+	return it.current
+}
+
+// Err returns the first error encountered by the iterator, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// StatusCheckIterator iterates over the pages of a project's external
+// status checks. See ListProjectStatusChecksIter.
+type StatusCheckIterator = Iterator[ProjectStatusCheck]
+
+// ListProjectStatusChecksIter returns an iterator over a project's
+// external status checks, transparently following pagination instead of
+// requiring the caller to loop on ListOptions.Page. Passing WithContext
+// among options bounds how long the iterator keeps fetching pages; once
+// that context is canceled, the next page fetch fails, so Next returns
+// false and Err reports why. Items of a page fetched before the
+// cancellation are still returned.
+func (s *ExternalStatusChecksService) ListProjectStatusChecksIter(pid interface{}, opt *ListOptions, options ...RequestOptionFunc) *StatusCheckIterator {
+	if opt == nil {
+		opt = &ListOptions{}
+	}
+
+	return newIterator(opt.Page, func(page int) ([]*ProjectStatusCheck, *Response, error) {
+		pageOpt := *opt
+		pageOpt.Page = page
+		return s.ListProjectStatusChecks(pid, &pageOpt, options...)
+	})
+}
@@ -0,0 +1,146 @@
+package gitlab
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// defaultRetryableStatusCodes are the response status codes that are
+// retried by default for requests other than POSTs, whether or not the
+// request also allow-lists codes via WithRetryOn. retryPolicy retries any
+// other 5xx response as well.
+var defaultRetryableStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:     true,
+	http.StatusInternalServerError: true,
+	http.StatusBadGateway:          true,
+	http.StatusServiceUnavailable:  true,
+	http.StatusGatewayTimeout:      true,
+}
+
+type retryOnContextKey struct{}
+
+// WithRetryOn allow-lists additional HTTP status codes as retryable for a
+// single request, on top of the 5xx responses and 429s that are retryable
+// by default. POST requests are not retried on the default codes, so for
+// them the allow-list is the complete set of retryable status codes. This
+// is primarily useful for non-idempotent requests (e.g. POSTs to
+// status_check_responses) that should only be retried for status codes the
+// caller knows are safe to resend, such as a 409 or 422 returned while
+// GitLab is still indexing a SHA.
+func WithRetryOn(codes ...int) RequestOptionFunc {
+	return func(req *retryablehttp.Request) error {
+		ctx := context.WithValue(req.Context(), retryOnContextKey{}, codes)
+		*req.Request = *req.Request.WithContext(ctx)
+		return nil
+	}
+}
+
+// WithRetryableHTTPClient configures the Client to send requests through a
+// retryablehttp.Client, transparently retrying transient failures (5xx
+// responses and 429s by default, plus anything allow-listed via
+// WithRetryOn; POSTs only for allow-listed codes) with exponential backoff
+// and jitter between attempts.
+//
+// The backoff honors the RateLimit-Reset and Retry-After headers GitLab
+// sends on throttled responses, capped at maxWait. When neither header is
+// present it falls back to full jitter: a random delay between 0 and
+// minWait*2^attempt, capped at maxWait.
+func WithRetryableHTTPClient(maxRetries int, minWait, maxWait time.Duration) ClientOptionFunc {
+	return func(c *Client) error {
+		c.client.RetryMax = maxRetries
+		c.client.RetryWaitMin = minWait
+		c.client.RetryWaitMax = maxWait
+		c.client.CheckRetry = retryPolicy
+		c.client.Backoff = retryBackoff
+		return nil
+	}
+}
+
+// retryPolicy decides whether a request should be retried. It always
+// retries on connection errors and on status codes allow-listed for the
+// request via WithRetryOn. POST requests are retried for nothing else;
+// other requests are also retried on any 5xx response and on the codes in
+// defaultRetryableStatusCodes.
+func retryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+	if err != nil {
+		return true, nil
+	}
+	if resp == nil {
+		return false, nil
+	}
+
+	if codes, ok := ctx.Value(retryOnContextKey{}).([]int); ok {
+		for _, code := range codes {
+			if resp.StatusCode == code {
+				return true, nil
+			}
+		}
+	}
+
+	// POSTs are not idempotent, so only the allow-listed codes above apply.
+	if resp.Request != nil && resp.Request.Method == http.MethodPost {
+		return false, nil
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return true, nil
+	}
+	return defaultRetryableStatusCodes[resp.StatusCode], nil
+}
+
+// retryBackoff computes the delay before the next retry attempt. It
+// prefers the GitLab-provided RateLimit-Reset and Retry-After headers
+// (capped at max), and otherwise falls back to full jitter: a random delay
+// in [0, min*2^attemptNum), with min*2^attemptNum capped at max.
+func retryBackoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := waitFromHeaders(resp, max); ok {
+			return wait
+		}
+	}
+
+	mult := math.Pow(2, float64(attemptNum)) * float64(min)
+	wait := time.Duration(mult)
+	if wait > max || wait <= 0 {
+		wait = max
+	}
+
+	// Full jitter: sleep for a random duration between 0 and wait.
+	return time.Duration(rand.Int63n(int64(wait)))
+}
+
+// waitFromHeaders computes a retry delay from the RateLimit-Reset or
+// Retry-After headers of resp, if present.
+func waitFromHeaders(resp *http.Response, max time.Duration) (time.Duration, bool) {
+	if v := resp.Header.Get("RateLimit-Reset"); v != "" {
+		if reset, err := strconv.ParseInt(v, 10, 64); err == nil {
+			if wait := time.Until(time.Unix(reset, 0)); wait > 0 {
+				return minDuration(wait, max), true
+			}
+		}
+	}
+
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil && seconds > 0 {
+			return minDuration(time.Duration(seconds)*time.Second, max), true
+		}
+	}
+
+	return 0, false
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
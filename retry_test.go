@@ -0,0 +1,218 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_DefaultStatusCodes(t *testing.T) {
+	tests := []struct {
+		status int
+		retry  bool
+	}{
+		{http.StatusOK, false},
+		{http.StatusNotFound, false},
+		{http.StatusUnprocessableEntity, false},
+		{http.StatusTooManyRequests, true},
+		{http.StatusInternalServerError, true},
+		{http.StatusBadGateway, true},
+		{http.StatusServiceUnavailable, true},
+		{http.StatusGatewayTimeout, true},
+	}
+
+	for _, tt := range tests {
+		resp := &http.Response{StatusCode: tt.status}
+		retry, err := retryPolicy(context.Background(), resp, nil)
+		if err != nil {
+			t.Fatalf("status %d: unexpected error: %v", tt.status, err)
+		}
+		if retry != tt.retry {
+			t.Errorf("status %d: got retry=%v, want %v", tt.status, retry, tt.retry)
+		}
+	}
+}
+
+func TestRetryPolicy_WithRetryOnAllowList(t *testing.T) {
+	ctx := context.WithValue(context.Background(), retryOnContextKey{}, []int{409, 422})
+
+	retry, err := retryPolicy(ctx, &http.Response{StatusCode: 422}, nil)
+	if err != nil || !retry {
+		t.Errorf("expected allow-listed 422 to be retried, got retry=%v err=%v", retry, err)
+	}
+
+	// The default retryable codes still apply on top of the allow-list.
+	retry, err = retryPolicy(ctx, &http.Response{StatusCode: http.StatusInternalServerError}, nil)
+	if err != nil || !retry {
+		t.Errorf("expected default-retryable 500 to still be retried, got retry=%v err=%v", retry, err)
+	}
+
+	retry, err = retryPolicy(ctx, &http.Response{StatusCode: http.StatusNotFound}, nil)
+	if err != nil || retry {
+		t.Errorf("expected non-allow-listed, non-default 404 to not be retried, got retry=%v err=%v", retry, err)
+	}
+}
+
+func TestRetryPolicy_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	retry, err := retryPolicy(ctx, &http.Response{StatusCode: http.StatusServiceUnavailable}, nil)
+	if err == nil || retry {
+		t.Errorf("expected canceled context to stop retries, got retry=%v err=%v", retry, err)
+	}
+}
+
+func TestRetryBackoff_JitterBounds(t *testing.T) {
+	min := 100 * time.Millisecond
+	max := 2 * time.Second
+
+	for attempt := 0; attempt < 6; attempt++ {
+		for i := 0; i < 20; i++ {
+			wait := retryBackoff(min, max, attempt, nil)
+			if wait < 0 || wait > max {
+				t.Fatalf("attempt %d: wait %v out of bounds [0, %v]", attempt, wait, max)
+			}
+		}
+	}
+}
+
+func TestRetryBackoff_HonorsRetryAfterHeader(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+
+	wait := retryBackoff(100*time.Millisecond, 10*time.Second, 0, resp)
+	if wait != 3*time.Second {
+		t.Errorf("got wait=%v, want 3s", wait)
+	}
+}
+
+func TestRetryBackoff_RetryAfterClampedToMax(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"30"}}}
+
+	wait := retryBackoff(100*time.Millisecond, 5*time.Second, 0, resp)
+	if wait != 5*time.Second {
+		t.Errorf("got wait=%v, want clamped 5s", wait)
+	}
+}
+
+func TestRetryBackoff_HonorsRateLimitResetHeader(t *testing.T) {
+	reset := time.Now().Add(2 * time.Second)
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+
+	wait := retryBackoff(100*time.Millisecond, 10*time.Second, 0, resp)
+	if wait <= 0 || wait > 2*time.Second {
+		t.Errorf("got wait=%v, want roughly <= 2s", wait)
+	}
+}
+
+// TestClientDo_RetriesWithRetryableHTTPClient exercises the wiring rather
+// than the policy math above: a Client configured with
+// WithRetryableHTTPClient must actually resend a request that fails with a
+// default-retryable status code, and stop retrying once it succeeds.
+func TestClientDo_RetriesWithRetryableHTTPClient(t *testing.T) {
+	var attempts int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/version", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"version":"1.0","revision":"abc"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient("", WithBaseURL(server.URL), WithRetryableHTTPClient(5, time.Millisecond, 10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %v", err)
+	}
+
+	req, err := client.NewRequest(http.MethodGet, "version", nil, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() returned error: %v", err)
+	}
+
+	if _, err := client.Do(req, nil); err != nil {
+		t.Fatalf("Do() returned error: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("attempts = %d, want 3 (two 503s then a 200)", got)
+	}
+}
+
+// TestClientDo_DoesNotRetryNonAllowListedStatus asserts a request is not
+// retried for a status code that is neither default-retryable nor
+// allow-listed via WithRetryOn, even though the Client is configured to
+// retry in general.
+func TestClientDo_DoesNotRetryNonAllowListedStatus(t *testing.T) {
+	var attempts int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/version", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient("", WithBaseURL(server.URL), WithRetryableHTTPClient(5, time.Millisecond, 10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %v", err)
+	}
+
+	req, err := client.NewRequest(http.MethodGet, "version", nil, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() returned error: %v", err)
+	}
+
+	if _, err := client.Do(req, nil); err == nil {
+		t.Fatalf("expected an error from a non-retried 400")
+	}
+
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Errorf("attempts = %d, want 1 (400 is not retryable by default or allow-listed)", got)
+	}
+}
+
+// TestClientDo_RetriesPostWithRetryOnAllowList exercises WithRetryOn
+// end-to-end: SetExternalStatusCheckStatus is a POST that allow-lists 422
+// via WithRetryOn, and this proves that allow-list actually reaches
+// retryPolicy through the request context (retry.go's
+// *req.Request = req.Request.WithContext(ctx) wiring), not just that
+// retryPolicy behaves correctly when handed a hand-built context.
+func TestClientDo_RetriesPostWithRetryOnAllowList(t *testing.T) {
+	var attempts int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/1/merge_requests/2/status_check_responses", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient("", WithBaseURL(server.URL), WithRetryableHTTPClient(5, time.Millisecond, 10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewClient() returned error: %v", err)
+	}
+
+	opt := &SetExternalStatusCheckStatusOptions{}
+	if _, err := client.ExternalStatusChecks.SetExternalStatusCheckStatus(1, 2, opt); err != nil {
+		t.Fatalf("SetExternalStatusCheckStatus() returned error: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("attempts = %d, want 3 (two allow-listed 422s then a 200)", got)
+	}
+}